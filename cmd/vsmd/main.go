@@ -0,0 +1,116 @@
+// Command vsmd serves a *vsm.VSM over gRPC and HTTP/JSON, loading its
+// corpus from -corpus on startup and persisting it back on exit.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/quan-to/go-vsm/vsm"
+	"github.com/quan-to/go-vsm/vsmserver"
+)
+
+func main() {
+	var (
+		corpusPath = flag.String("corpus", "", "path to load/save the trained corpus (gob format); empty starts with an untrained corpus and disables saving on exit")
+		analyzer   = flag.String("analyzer", "standard", "tokenizer/analyzer to use: standard, english or ngram:N")
+		scorer     = flag.String("scorer", "cosine", "ranking scorer to use: cosine or bm25")
+		httpAddr   = flag.String("http-addr", ":8080", "address to serve the HTTP/JSON gateway on")
+		grpcAddr   = flag.String("grpc-addr", ":8081", "address to serve gRPC on")
+	)
+	flag.Parse()
+
+	a, err := parseAnalyzer(*analyzer)
+	if err != nil {
+		log.Fatalf("vsmd: %s", err)
+	}
+
+	s, err := parseScorer(*scorer)
+	if err != nil {
+		log.Fatalf("vsmd: %s", err)
+	}
+
+	v := vsm.New(nil, vsm.WithAnalyzer(a), vsm.WithScorer(s))
+
+	if *corpusPath != "" {
+		if err := v.LoadFile(*corpusPath); err != nil && !os.IsNotExist(err) {
+			log.Fatalf("vsmd: loading corpus from %q: %s", *corpusPath, err)
+		}
+	}
+
+	srv := vsmserver.New(v)
+
+	lis, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		log.Fatalf("vsmd: listening on %q: %s", *grpcAddr, err)
+	}
+
+	gs := grpc.NewServer()
+	vsmserver.RegisterGRPC(gs, srv)
+
+	go func() {
+		log.Printf("vsmd: serving gRPC on %s", *grpcAddr)
+		if err := gs.Serve(lis); err != nil {
+			log.Fatalf("vsmd: gRPC server: %s", err)
+		}
+	}()
+
+	go func() {
+		log.Printf("vsmd: serving HTTP/JSON on %s", *httpAddr)
+		if err := http.ListenAndServe(*httpAddr, vsmserver.NewHTTPHandler(srv)); err != nil {
+			log.Fatalf("vsmd: HTTP server: %s", err)
+		}
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+
+	gs.GracefulStop()
+
+	if *corpusPath == "" {
+		return
+	}
+
+	if err := v.SaveFile(*corpusPath); err != nil {
+		log.Fatalf("vsmd: saving corpus to %q: %s", *corpusPath, err)
+	}
+}
+
+// parseAnalyzer resolves the -analyzer flag to a vsm.Analyzer.
+func parseAnalyzer(name string) (vsm.Analyzer, error) {
+	switch {
+	case name == "standard":
+		return vsm.StandardAnalyzer(), nil
+	case name == "english":
+		return vsm.EnglishAnalyzer(), nil
+	case len(name) > 6 && name[:6] == "ngram:":
+		var n int
+		if _, err := fmt.Sscanf(name[6:], "%d", &n); err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid ngram size in -analyzer=%q", name)
+		}
+		return vsm.NGramAnalyzer(n), nil
+	default:
+		return nil, fmt.Errorf("unknown -analyzer=%q: want standard, english or ngram:N", name)
+	}
+}
+
+// parseScorer resolves the -scorer flag to a vsm.Scorer.
+func parseScorer(name string) (vsm.Scorer, error) {
+	switch name {
+	case "cosine":
+		return vsm.CosineScorer{}, nil
+	case "bm25":
+		return vsm.NewBM25Scorer(), nil
+	default:
+		return nil, fmt.Errorf("unknown -scorer=%q: want cosine or bm25", name)
+	}
+}
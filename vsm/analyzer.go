@@ -0,0 +1,186 @@
+package vsm
+
+import "strings"
+
+// Analyzer turns a raw sentence into the sequence of terms used to
+// build or query the vector space model. The same Analyzer must be
+// applied to both indexed documents and queries for scores to be
+// meaningful, which is why it's configured once on the VSM via
+// WithAnalyzer rather than passed to StaticTraining or Search.
+//
+// StandardAnalyzer, EnglishAnalyzer and NGramAnalyzer cover the
+// common cases; any type satisfying Analyzer can be used instead.
+type Analyzer interface {
+	Tokenize(sentence string) []string
+}
+
+// TokenFilter transforms or drops tokens produced by an Analyzer's
+// tokenizer. Filters run in the order they're configured, each
+// seeing the previous filter's output.
+type TokenFilter interface {
+	Filter(tokens []string) []string
+}
+
+// pipelineAnalyzer is an Analyzer built from a raw tokenize func and
+// a chain of TokenFilters applied in order. StandardAnalyzer,
+// EnglishAnalyzer and NGramAnalyzer all return one.
+type pipelineAnalyzer struct {
+	tokenize func(sentence string) []string
+	filters  []TokenFilter
+}
+
+// Tokenize implements Analyzer.
+func (a *pipelineAnalyzer) Tokenize(sentence string) []string {
+	tokens := a.tokenize(sentence)
+
+	for _, f := range a.filters {
+		tokens = f.Filter(tokens)
+	}
+
+	return tokens
+}
+
+// whitespaceTokenize splits a sentence on runs of whitespace.
+func whitespaceTokenize(sentence string) []string {
+	return strings.Fields(sentence)
+}
+
+// StandardAnalyzer splits a sentence on whitespace and lowercases
+// each token, dropping any that end up empty. It's the Analyzer
+// used by New when no WithAnalyzer option is given.
+func StandardAnalyzer() Analyzer {
+	return &pipelineAnalyzer{
+		tokenize: whitespaceTokenize,
+		filters:  []TokenFilter{LowercaseFilter{}},
+	}
+}
+
+// EnglishAnalyzer extends StandardAnalyzer with English stopword
+// removal and Porter stemming. It trades a bit of precision for
+// better recall on English corpora, matching queries like "running"
+// against documents containing "runs".
+func EnglishAnalyzer() Analyzer {
+	return &pipelineAnalyzer{
+		tokenize: whitespaceTokenize,
+		filters: []TokenFilter{
+			LowercaseFilter{},
+			NewStopwordFilter(EnglishStopwords),
+			StemFilter{},
+		},
+	}
+}
+
+// NGramAnalyzer returns an Analyzer that indexes word n-grams of the
+// given size instead of single terms. A sentence tokenizes to one
+// term per n consecutive words (e.g. with n=2, "gold silver truck"
+// becomes the terms "gold_silver" and "silver_truck"), which can
+// improve precision on short, phrase-like queries at the cost of
+// vocabulary size.
+func NGramAnalyzer(n int) Analyzer {
+	return &pipelineAnalyzer{
+		tokenize: whitespaceTokenize,
+		filters: []TokenFilter{
+			LowercaseFilter{},
+			NGramFilter{N: n},
+		},
+	}
+}
+
+// LowercaseFilter lowercases each token, trims surrounding
+// whitespace and drops tokens that become empty.
+type LowercaseFilter struct{}
+
+// Filter implements TokenFilter.
+func (LowercaseFilter) Filter(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+
+	for _, tok := range tokens {
+		tok = strings.ToLower(strings.TrimSpace(tok))
+		if tok == "" {
+			continue
+		}
+
+		out = append(out, tok)
+	}
+
+	return out
+}
+
+// MinLengthFilter drops any token shorter than Min runes.
+type MinLengthFilter struct {
+	Min int
+}
+
+// Filter implements TokenFilter.
+func (f MinLengthFilter) Filter(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+
+	for _, tok := range tokens {
+		if len([]rune(tok)) < f.Min {
+			continue
+		}
+
+		out = append(out, tok)
+	}
+
+	return out
+}
+
+// StopwordFilter drops any token present in Words.
+type StopwordFilter struct {
+	Words map[string]struct{}
+}
+
+// NewStopwordFilter returns a StopwordFilter that drops every token
+// in words.
+func NewStopwordFilter(words []string) StopwordFilter {
+	set := make(map[string]struct{}, len(words))
+
+	for _, w := range words {
+		set[w] = struct{}{}
+	}
+
+	return StopwordFilter{Words: set}
+}
+
+// Filter implements TokenFilter.
+func (f StopwordFilter) Filter(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+
+	for _, tok := range tokens {
+		if _, ok := f.Words[tok]; ok {
+			continue
+		}
+
+		out = append(out, tok)
+	}
+
+	return out
+}
+
+// NGramFilter replaces the token stream with the sequence of word
+// n-grams of size N, each joined with "_" (e.g. the tokens "war",
+// "of", "the", "worlds" become "war_of", "of_the", "the_worlds" for
+// N=2). A token stream shorter than N yields no terms.
+type NGramFilter struct {
+	N int
+}
+
+// Filter implements TokenFilter.
+func (f NGramFilter) Filter(tokens []string) []string {
+	if f.N <= 1 {
+		return tokens
+	}
+
+	if len(tokens) < f.N {
+		return nil
+	}
+
+	grams := make([]string, 0, len(tokens)-f.N+1)
+
+	for i := 0; i+f.N <= len(tokens); i++ {
+		grams = append(grams, strings.Join(tokens[i:i+f.N], "_"))
+	}
+
+	return grams
+}
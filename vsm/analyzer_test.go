@@ -0,0 +1,63 @@
+package vsm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStandardAnalyzer(t *testing.T) {
+	got := StandardAnalyzer().Tokenize("Shipment of  Gold.")
+	want := []string{"shipment", "of", "gold."}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v tokens; want %v.", got, want)
+	}
+}
+
+func TestEnglishAnalyzer(t *testing.T) {
+	got := EnglishAnalyzer().Tokenize("The ships are running in the fires")
+	want := []string{"ship", "run", "fire"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v tokens; want %v.", got, want)
+	}
+}
+
+func TestNGramAnalyzer(t *testing.T) {
+	got := NGramAnalyzer(2).Tokenize("gold silver truck")
+	want := []string{"gold_silver", "silver_truck"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %v tokens; want %v.", got, want)
+	}
+}
+
+func TestVSMSearchEnglishAnalyzer(t *testing.T) {
+	docs := []Document{
+		{Sentence: "Ships carrying gold are running late.", Class: "d1"},
+		{Sentence: "Deliveries of silver trucks arrived.", Class: "d2"},
+	}
+
+	vsm := New(nil, WithAnalyzer(EnglishAnalyzer()))
+
+	for _, doc := range docs {
+		if err := vsm.StaticTraining(doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	// "ship" should match "Ships" once the query is stemmed, which a
+	// whitespace-only analyzer could never do.
+	got, err := vsm.Search("ship", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) == 0 {
+		t.Fatal("Got no results, want at least one.")
+	}
+
+	if want := "d1"; got[0].Class != want {
+		t.Errorf("Got %q class; want %q.", got[0].Class, want)
+	}
+}
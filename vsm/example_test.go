@@ -30,9 +30,9 @@ func Example() {
 		fmt.Println(vsm.StaticTraining(doc))
 	}
 
-	doc, err := vsm.Search("gold silver truck.")
+	results, err := vsm.Search("gold silver truck.", 1)
 
-	fmt.Println(doc.Class, err)
+	fmt.Println(results[0].Class, err)
 	// Output:
 	// <nil>
 	// <nil>
@@ -72,9 +72,9 @@ func ExampleVSM_Search() {
 		fmt.Println(vsm.StaticTraining(doc))
 	}
 
-	doc, err := vsm.Search("shipment gold in a flying truck.")
+	results, err := vsm.Search("shipment gold in a flying truck.", 1)
 
-	fmt.Println(doc.Class, err)
+	fmt.Println(results[0].Class, err)
 	// Output:
 	// <nil>
 	// <nil>
@@ -124,9 +124,9 @@ func ExampleVSM_DynamicTraining() {
 		}
 	}
 
-	doc, err := vsm.Search("gold silver truck.")
+	results, err := vsm.Search("gold silver truck.", 1)
 
-	fmt.Println(doc.Class, err)
+	fmt.Println(results[0].Class, err)
 	// Output:
 	// <nil>
 	// <nil>
@@ -0,0 +1,116 @@
+package vsm
+
+import "testing"
+
+func TestTrieFuzzySearch(t *testing.T) {
+	tr := newTrie()
+	for _, w := range []string{"shipment", "shipping", "ship", "truck"} {
+		tr.Insert(w)
+	}
+
+	testCases := []struct {
+		word     string
+		maxEdits int
+		want     map[string]int
+	}{
+		{word: "ship", maxEdits: 0, want: map[string]int{"ship": 0}},
+		{word: "shpi", maxEdits: 1, want: map[string]int{}},
+		{word: "shipmemt", maxEdits: 1, want: map[string]int{"shipment": 1}},
+		{word: "shop", maxEdits: 1, want: map[string]int{"ship": 1}},
+		{word: "trucks", maxEdits: 1, want: map[string]int{"truck": 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.word, func(t *testing.T) {
+			got := make(map[string]int)
+			for _, m := range tr.FuzzySearch(tc.word, tc.maxEdits) {
+				got[m.Term] = m.Edits
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("Got %+v; want %+v.", got, tc.want)
+			}
+
+			for term, edits := range tc.want {
+				if got[term] != edits {
+					t.Errorf("Got %d edits for %q; want %d.", got[term], term, edits)
+				}
+			}
+		})
+	}
+}
+
+func TestTrieRemove(t *testing.T) {
+	tr := newTrie()
+	for _, w := range []string{"shipment", "shipping", "ship"} {
+		tr.Insert(w)
+	}
+
+	tr.Remove("shipment")
+
+	got := make(map[string]int)
+	for _, m := range tr.FuzzySearch("shipment", 0) {
+		got[m.Term] = m.Edits
+	}
+	if len(got) != 0 {
+		t.Errorf("Got %+v after removing \"shipment\"; want none.", got)
+	}
+
+	got = make(map[string]int)
+	for _, m := range tr.FuzzySearch("ship", 0) {
+		got[m.Term] = m.Edits
+	}
+	if want := (map[string]int{"ship": 0}); len(got) != len(want) || got["ship"] != 0 {
+		t.Errorf("Got %+v after removing an unrelated word; want %+v still present.", got, want)
+	}
+
+	// Removing a word not in the dictionary is harmless.
+	tr.Remove("nonexistent")
+}
+
+func TestVSMSearchFuzziness(t *testing.T) {
+	docs := []Document{
+		{
+			Sentence: "Shipment of gold damaged in a fire.",
+			Class:    "d1",
+		},
+		{
+			Sentence: "Delivery of silver arrived in a truck.",
+			Class:    "d2",
+		},
+	}
+
+	vsm := New(nil)
+
+	for _, doc := range docs {
+		if err := vsm.StaticTraining(doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	t.Run("without fuzziness", func(t *testing.T) {
+		got, err := vsm.Search("shipmemt gol", 1)
+		if err != nil {
+			t.Fatalf("Got error while searching: %q; want nil.", err)
+		}
+
+		if len(got) != 0 {
+			t.Errorf("Got %+v; want no results without WithFuzziness.", got)
+		}
+	})
+
+	t.Run("with fuzziness", func(t *testing.T) {
+		got, err := vsm.Search("shipmemt gol", 1, WithFuzziness(1))
+		if err != nil {
+			t.Fatalf("Got error while searching: %q; want nil.", err)
+		}
+
+		if len(got) == 0 {
+			t.Fatal("Got no results, want at least one.")
+		}
+
+		if want := "d1"; got[0].Class != want {
+			t.Errorf("Got %q class; want %q.", got[0].Class, want)
+		}
+	})
+}
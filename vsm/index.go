@@ -0,0 +1,77 @@
+package vsm
+
+import "sync"
+
+// docID identifies a document within a VSM's corpus. It's assigned
+// sequentially by StaticTraining and never reused, even after the
+// document is removed by Delete.
+type docID uint64
+
+// posting is one document's occurrence of a term: how many times
+// the term appears in that document.
+type posting struct {
+	id       docID
+	termFreq uint64
+}
+
+// invertedIndex maps each term to the postings list of documents
+// containing it, so Search can walk only the documents sharing a
+// term with the query instead of scanning the whole corpus.
+type invertedIndex struct {
+	mu       sync.RWMutex
+	postings map[string][]posting
+}
+
+// newInvertedIndex returns an empty invertedIndex.
+func newInvertedIndex() *invertedIndex {
+	return &invertedIndex{postings: make(map[string][]posting)}
+}
+
+// Add appends a posting for id's occurrence of trm.
+func (idx *invertedIndex) Add(trm string, id docID, termFreq uint64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.postings[trm] = append(idx.postings[trm], posting{id: id, termFreq: termFreq})
+}
+
+// Get returns a copy of the postings list for trm, or nil if no
+// indexed document contains it. It copies rather than returning the
+// backing slice directly because Remove shifts that array in place;
+// without the copy, a caller ranging over the result could race with
+// a concurrent Remove rewriting the same memory out from under it.
+func (idx *invertedIndex) Get(trm string) []posting {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	postings := idx.postings[trm]
+	if postings == nil {
+		return nil
+	}
+
+	cp := make([]posting, len(postings))
+	copy(cp, postings)
+	return cp
+}
+
+// Remove deletes id's posting from trm's postings list, dropping
+// the term entirely once its postings list becomes empty.
+func (idx *invertedIndex) Remove(trm string, id docID) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	postings := idx.postings[trm]
+	for i, p := range postings {
+		if p.id == id {
+			postings = append(postings[:i], postings[i+1:]...)
+			break
+		}
+	}
+
+	if len(postings) == 0 {
+		delete(idx.postings, trm)
+		return
+	}
+
+	idx.postings[trm] = postings
+}
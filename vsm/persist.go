@@ -0,0 +1,175 @@
+package vsm
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// snapshotVersion is bumped whenever the on-disk format Save/Load
+// use changes in a way Load can't handle transparently.
+const snapshotVersion = 1
+
+// snapshot is the on-disk representation of a trained VSM. Every
+// field is exported so encoding/gob can see it; VSM's own types stay
+// unexported. It captures the term dictionary, the inverted index's
+// postings and the indexed documents, but not the VSM's Transformer,
+// Scorer or Analyzer, which the caller must configure the same way
+// on both ends of a Save/Load round trip.
+type snapshot struct {
+	Version        int
+	DocsCount      uint64
+	TotalTermCount uint64
+	NextID         uint64
+	Docs           map[uint64]docSnapshot
+	Terms          map[string]uint64
+	Postings       map[string][]postingSnapshot
+}
+
+// docSnapshot is the serialized form of a document.
+type docSnapshot struct {
+	Sentence string
+	Class    string
+	TermFreq map[string]uint64
+	DocLen   uint64
+}
+
+// postingSnapshot is the serialized form of a posting.
+type postingSnapshot struct {
+	ID       uint64
+	TermFreq uint64
+}
+
+// Save serializes the trained corpus to w with encoding/gob, so it
+// can later be restored with Load without retraining. It does not
+// persist the VSM's Transformer, Scorer or Analyzer.
+func (v *VSM) Save(w io.Writer) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	snap := snapshot{
+		Version:        snapshotVersion,
+		DocsCount:      atomic.LoadUint64(&v.docsCount),
+		TotalTermCount: atomic.LoadUint64(&v.totalTermCount),
+		NextID:         uint64(v.nextID),
+		Docs:           make(map[uint64]docSnapshot, len(v.docs)),
+		Terms:          make(map[string]uint64),
+		Postings:       make(map[string][]postingSnapshot),
+	}
+
+	for id, doc := range v.docs {
+		snap.Docs[uint64(id)] = docSnapshot{
+			Sentence: doc.Sentence,
+			Class:    doc.Class,
+			TermFreq: doc.termFreq,
+			DocLen:   doc.docLen,
+		}
+	}
+
+	v.terms.mu.RLock()
+	for trm, t := range v.terms.terms {
+		snap.Terms[trm] = t.docsSeen
+	}
+	v.terms.mu.RUnlock()
+
+	v.index.mu.RLock()
+	for trm, postings := range v.index.postings {
+		list := make([]postingSnapshot, len(postings))
+		for i, p := range postings {
+			list[i] = postingSnapshot{ID: uint64(p.id), TermFreq: p.termFreq}
+		}
+		snap.Postings[trm] = list
+	}
+	v.index.mu.RUnlock()
+
+	return gob.NewEncoder(w).Encode(snap)
+}
+
+// SaveFile creates (or truncates) the file at path and writes the
+// trained corpus to it, as Save does.
+func (v *VSM) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+
+	if err := v.Save(bw); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// Load replaces the VSM's corpus with one previously written by
+// Save. The VSM's Transformer, Scorer and Analyzer are left as they
+// are; only the trained documents, terms and postings are restored.
+func (v *VSM) Load(r io.Reader) error {
+	var snap snapshot
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return err
+	}
+
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("vsm: snapshot version %d is incompatible with %d", snap.Version, snapshotVersion)
+	}
+
+	docs := make(map[docID]document, len(snap.Docs))
+	for id, ds := range snap.Docs {
+		docs[docID(id)] = document{
+			Document: Document{Sentence: ds.Sentence, Class: ds.Class},
+			termFreq: ds.TermFreq,
+			docLen:   ds.DocLen,
+		}
+	}
+
+	termsMap := make(map[string]term, len(snap.Terms))
+	for trm, docsSeen := range snap.Terms {
+		termsMap[trm] = term{docsSeen: docsSeen}
+	}
+
+	postings := make(map[string][]posting, len(snap.Postings))
+	for trm, list := range snap.Postings {
+		ps := make([]posting, len(list))
+		for i, p := range list {
+			ps[i] = posting{id: docID(p.ID), termFreq: p.TermFreq}
+		}
+		postings[trm] = ps
+	}
+
+	dict := newTrie()
+	for trm := range termsMap {
+		dict.Insert(trm)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.docs = docs
+	v.nextID = docID(snap.NextID)
+	v.terms = &terms{terms: termsMap}
+	v.index = &invertedIndex{postings: postings}
+	v.dict = dict
+
+	atomic.StoreUint64(&v.docsCount, snap.DocsCount)
+	atomic.StoreUint64(&v.totalTermCount, snap.TotalTermCount)
+
+	return nil
+}
+
+// LoadFile opens the file at path and replaces the VSM's corpus
+// with its contents, as Load does.
+func (v *VSM) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return v.Load(bufio.NewReader(f))
+}
@@ -0,0 +1,145 @@
+package vsm
+
+import (
+	"bytes"
+	"encoding/gob"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestVSMSaveLoad(t *testing.T) {
+	docs := []Document{
+		{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"},
+		{Sentence: "Delivery of silver arrived in a silver truck.", Class: "d2"},
+	}
+
+	vsm := New(nil)
+
+	for _, doc := range docs {
+		if err := vsm.StaticTraining(doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := vsm.Save(&buf); err != nil {
+		t.Fatalf("Got error while saving: %q; want nil.", err)
+	}
+
+	restored := New(nil)
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Got error while loading: %q; want nil.", err)
+	}
+
+	got, err := restored.Search("gold silver truck.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 1 || got[0].Class != "d2" {
+		t.Errorf("Got %+v; want a single d2 result.", got)
+	}
+}
+
+func TestVSMSaveLoadFile(t *testing.T) {
+	vsm := New(nil)
+
+	if err := vsm.StaticTraining(Document{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while training: %q; want nil.", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "corpus.gob")
+
+	if err := vsm.SaveFile(path); err != nil {
+		t.Fatalf("Got error while saving to file: %q; want nil.", err)
+	}
+
+	restored := New(nil)
+	if err := restored.LoadFile(path); err != nil {
+		t.Fatalf("Got error while loading from file: %q; want nil.", err)
+	}
+
+	got, err := restored.Search("gold fire.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 1 || got[0].Class != "d1" {
+		t.Errorf("Got %+v; want a single d1 result.", got)
+	}
+}
+
+// TestVSMConcurrentSearchAndLoad exercises Search running
+// concurrently with Load, the scenario that used to race: Load
+// swaps v.terms, v.index and v.dict out for brand-new instances
+// while holding only v.mu, while Search read those fields without
+// holding it at all. Run with -race to catch a regression.
+func TestVSMConcurrentSearchAndLoad(t *testing.T) {
+	vsm := New(nil)
+
+	if err := vsm.StaticTraining(Document{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while training: %q; want nil.", err)
+	}
+
+	var buf bytes.Buffer
+	if err := vsm.Save(&buf); err != nil {
+		t.Fatalf("Got error while saving: %q; want nil.", err)
+	}
+	snapshot := buf.Bytes()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := vsm.Search("gold fire.", 1); err != nil {
+					t.Errorf("Got error while searching: %q; want nil.", err)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := vsm.Load(bytes.NewReader(snapshot)); err != nil {
+				t.Errorf("Got error while loading: %q; want nil.", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestVSMLoadVersionMismatch(t *testing.T) {
+	vsm := New(nil)
+
+	if err := vsm.StaticTraining(Document{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while training: %q; want nil.", err)
+	}
+
+	var buf bytes.Buffer
+	if err := vsm.Save(&buf); err != nil {
+		t.Fatalf("Got error while saving: %q; want nil.", err)
+	}
+
+	var snap snapshot
+	if err := gob.NewDecoder(&buf).Decode(&snap); err != nil {
+		t.Fatalf("Got error while decoding: %q; want nil.", err)
+	}
+
+	snap.Version++
+
+	var corrupted bytes.Buffer
+	if err := gob.NewEncoder(&corrupted).Encode(snap); err != nil {
+		t.Fatalf("Got error while re-encoding: %q; want nil.", err)
+	}
+
+	if err := New(nil).Load(&corrupted); err == nil {
+		t.Errorf("Got nil error loading a snapshot with version %d; want an error.", snap.Version)
+	}
+}
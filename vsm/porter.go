@@ -0,0 +1,293 @@
+package vsm
+
+import "strings"
+
+// StemFilter reduces each token to its word stem using a Porter-style
+// stemming algorithm (Porter, 1980), so that morphological variants
+// such as "running", "runs" and "ran" collapse to a single indexed
+// term. It's one of the filters EnglishAnalyzer applies.
+type StemFilter struct{}
+
+// Filter implements TokenFilter.
+func (StemFilter) Filter(tokens []string) []string {
+	out := make([]string, len(tokens))
+
+	for i, tok := range tokens {
+		out[i] = porterStem(tok)
+	}
+
+	return out
+}
+
+// isConsonant reports whether the rune at index i of word is a
+// consonant, per the Porter algorithm's definition: "y" counts as a
+// consonant only when it's not preceded by another consonant.
+func isConsonant(word []rune, i int) bool {
+	switch word[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		if i == 0 {
+			return true
+		}
+		return !isConsonant(word, i-1)
+	default:
+		return true
+	}
+}
+
+// measure returns the Porter algorithm's "m" value for word: the
+// number of consonant-vowel sequences, i.e. [C](VC)^m[V].
+func measure(word []rune) int {
+	var m int
+	i := 0
+
+	for i < len(word) && isConsonant(word, i) {
+		i++
+	}
+
+	for i < len(word) {
+		for i < len(word) && !isConsonant(word, i) {
+			i++
+		}
+		if i >= len(word) {
+			break
+		}
+		for i < len(word) && isConsonant(word, i) {
+			i++
+		}
+		m++
+	}
+
+	return m
+}
+
+// containsVowel reports whether word has a vowel in [0, upTo).
+func containsVowel(word []rune, upTo int) bool {
+	for i := 0; i < upTo && i < len(word); i++ {
+		if !isConsonant(word, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsDoubleConsonant reports whether word ends in a double
+// consonant, e.g. "-tt", "-ss".
+func endsDoubleConsonant(word []rune) bool {
+	n := len(word)
+	if n < 2 {
+		return false
+	}
+	return word[n-1] == word[n-2] && isConsonant(word, n-1)
+}
+
+// endsCVC reports whether the last three letters of word are
+// consonant-vowel-consonant, where the final consonant isn't w, x or
+// y. This identifies words that need an "e" restored after suffix
+// stripping, e.g. "hop" -> "hope".
+func endsCVC(word []rune) bool {
+	n := len(word)
+	if n < 3 {
+		return false
+	}
+	if !isConsonant(word, n-3) || isConsonant(word, n-2) || !isConsonant(word, n-1) {
+		return false
+	}
+	switch word[n-1] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// trimSuffix removes suffix from word if present, returning the
+// stem and whether it matched.
+func trimSuffix(word, suffix string) (string, bool) {
+	if strings.HasSuffix(word, suffix) {
+		return strings.TrimSuffix(word, suffix), true
+	}
+	return word, false
+}
+
+// porterStem reduces word to its stem using the Porter stemming
+// algorithm. Words of two letters or fewer are returned unchanged,
+// matching the algorithm's definition that it only operates on
+// words with m > 0.
+func porterStem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+
+	// Step 1a: plural and -ed/-ing suffixes on "s".
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = strings.TrimSuffix(word, "sses") + "ss"
+	case strings.HasSuffix(word, "ies"):
+		word = strings.TrimSuffix(word, "ies") + "i"
+	case strings.HasSuffix(word, "ss"):
+		// unchanged
+	case strings.HasSuffix(word, "s"):
+		word = strings.TrimSuffix(word, "s")
+	}
+
+	// Step 1b: -eed/-ed/-ing.
+	switch {
+	case strings.HasSuffix(word, "eed"):
+		if stem := strings.TrimSuffix(word, "eed"); measure([]rune(stem)) > 0 {
+			word = stem + "ee"
+		}
+	case hasVowelSuffix(word, "ed") || hasVowelSuffix(word, "ing"):
+		var stem string
+		if s, ok := trimSuffix(word, "ed"); ok {
+			stem = s
+		} else if s, ok := trimSuffix(word, "ing"); ok {
+			stem = s
+		}
+
+		if containsVowel([]rune(stem), len(stem)) {
+			word = restoreStep1b(stem)
+		}
+	}
+
+	// Step 1c: terminal y preceded by a consonant, with a vowel
+	// earlier in the word, becomes i.
+	if strings.HasSuffix(word, "y") {
+		stem := strings.TrimSuffix(word, "y")
+		r := []rune(stem)
+		if containsVowel(r, len(r)) {
+			word = stem + "i"
+		}
+	}
+
+	word = step2(word)
+	word = step3(word)
+	word = step4(word)
+	word = step5(word)
+
+	return word
+}
+
+// hasVowelSuffix reports whether word ends in suffix and the part
+// before the suffix contains a vowel, the condition Step 1b uses to
+// decide whether -ed/-ing should be stripped at all.
+func hasVowelSuffix(word, suffix string) bool {
+	stem, ok := trimSuffix(word, suffix)
+	if !ok {
+		return false
+	}
+	r := []rune(stem)
+	return containsVowel(r, len(r))
+}
+
+// restoreStep1b applies Step 1b's cleanup after stripping -ed/-ing:
+// double letters are undone (except ll, ss, zz), or an "e" is added
+// back to monosyllabic CVC stems.
+func restoreStep1b(stem string) string {
+	switch {
+	case strings.HasSuffix(stem, "at"), strings.HasSuffix(stem, "bl"), strings.HasSuffix(stem, "iz"):
+		return stem + "e"
+	}
+
+	r := []rune(stem)
+	if endsDoubleConsonant(r) && r[len(r)-1] != 'l' && r[len(r)-1] != 's' && r[len(r)-1] != 'z' {
+		return string(r[:len(r)-1])
+	}
+
+	if measure(r) == 1 && endsCVC(r) {
+		return stem + "e"
+	}
+
+	return stem
+}
+
+// suffixRule is one (suffix, replacement) pair tried by Steps 2-4,
+// in order, guarded by a minimum stem measure.
+type suffixRule struct {
+	suffix, replacement string
+	minMeasure          int
+}
+
+func applyRules(word string, rules []suffixRule) string {
+	for _, r := range rules {
+		stem, ok := trimSuffix(word, r.suffix)
+		if !ok {
+			continue
+		}
+
+		if measure([]rune(stem)) >= r.minMeasure {
+			return stem + r.replacement
+		}
+
+		return word
+	}
+
+	return word
+}
+
+// step2 maps common double-suffixes (e.g. -ational, -iveness) onto
+// their single-suffix form, provided the stem has m > 0.
+func step2(word string) string {
+	return applyRules(word, []suffixRule{
+		{"ational", "ate", 1}, {"tional", "tion", 1}, {"enci", "ence", 1},
+		{"anci", "ance", 1}, {"izer", "ize", 1}, {"abli", "able", 1},
+		{"alli", "al", 1}, {"entli", "ent", 1}, {"eli", "e", 1},
+		{"ousli", "ous", 1}, {"ization", "ize", 1}, {"ation", "ate", 1},
+		{"ator", "ate", 1}, {"alism", "al", 1}, {"iveness", "ive", 1},
+		{"fulness", "ful", 1}, {"ousness", "ous", 1}, {"aliti", "al", 1},
+		{"iviti", "ive", 1}, {"biliti", "ble", 1},
+	})
+}
+
+// step3 maps a further set of suffixes (e.g. -icate, -ful, -ness)
+// onto their stem, again guarded by m > 0.
+func step3(word string) string {
+	return applyRules(word, []suffixRule{
+		{"icate", "ic", 1}, {"ative", "", 1}, {"alize", "al", 1},
+		{"iciti", "ic", 1}, {"ical", "ic", 1}, {"ful", "", 1}, {"ness", "", 1},
+	})
+}
+
+// step4 strips a final set of suffixes (e.g. -ement, -ion) from
+// stems with m > 1, leaving "-ion" only when it follows "s" or "t".
+func step4(word string) string {
+	suffixes := []string{
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant",
+		"ement", "ment", "ent", "ism", "ate", "iti", "ous", "ive", "ize",
+	}
+
+	for _, suf := range suffixes {
+		if stem, ok := trimSuffix(word, suf); ok && measure([]rune(stem)) > 1 {
+			return stem
+		}
+	}
+
+	if stem, ok := trimSuffix(word, "ion"); ok {
+		if n := len(stem); n > 0 && (stem[n-1] == 's' || stem[n-1] == 't') && measure([]rune(stem)) > 1 {
+			return stem
+		}
+	}
+
+	return word
+}
+
+// step5 strips a final "e" from stems with m > 1 (or m == 1 without
+// a trailing CVC), and collapses a trailing double "l" when m > 1.
+func step5(word string) string {
+	if stem, ok := trimSuffix(word, "e"); ok {
+		r := []rune(stem)
+		m := measure(r)
+		if m > 1 || (m == 1 && !endsCVC(r)) {
+			word = stem
+		}
+	}
+
+	if strings.HasSuffix(word, "ll") {
+		stem := strings.TrimSuffix(word, "l")
+		if measure([]rune(stem)) > 1 {
+			word = stem
+		}
+	}
+
+	return word
+}
@@ -0,0 +1,115 @@
+package vsm
+
+import "math"
+
+// Result holds a Document found by Search along with the
+// relevance score assigned by the configured Scorer. Results
+// are ordered by Score, descending.
+type Result struct {
+	Document
+
+	Score float64
+}
+
+// TermMatch holds the per-term statistics Search gathers from the
+// inverted index for a single document: how many times the term
+// occurs in the query and in that document, and how many documents
+// in the corpus contain it. Search builds one TermMatch per term the
+// query and a document have in common; a Scorer never sees terms
+// that only appear in one of them.
+type TermMatch struct {
+	Term      string
+	QueryFreq uint64
+	DocFreq   uint64
+	DocsSeen  uint64
+
+	// Weight discounts the term's contribution to the score. Exact
+	// matches use 1; fuzzy matches produced by WithFuzziness use
+	// 1/(1+edits), so a term found via a two-edit expansion counts
+	// for a third of an exact match.
+	Weight float64
+}
+
+// Scorer computes the relevance score of a document given a query,
+// based on the terms they have in common. Higher scores mean more
+// relevant. docNorm is the document's precomputed vector norm (see
+// document.norm), docLen is its length in tokens, totalDocs is the
+// corpus size and avgDocLen is the corpus' average document length.
+//
+// VSM ships with CosineScorer, the default, and BM25Scorer. Any type
+// satisfying Scorer can be passed to WithScorer.
+type Scorer interface {
+	Score(matches []TermMatch, docNorm, docLen, avgDocLen float64, totalDocs uint64) float64
+}
+
+// CosineScorer scores documents by weighting matched terms with
+// TF-IDF and dividing their dot product by the document's
+// precomputed vector norm. It is the Scorer used by New.
+//
+// The query vector's own norm is constant across every document
+// scored within a single Search call, so omitting it from the
+// division doesn't change the ranking, only the scale of Score.
+type CosineScorer struct{}
+
+// Score implements Scorer.
+func (CosineScorer) Score(matches []TermMatch, docNorm, docLen, avgDocLen float64, totalDocs uint64) float64 {
+	if docNorm == 0 {
+		return 0
+	}
+
+	var coeff float64
+
+	for _, m := range matches {
+		if m.DocsSeen == 0 {
+			continue
+		}
+
+		w := idf(totalDocs, m.DocsSeen)
+
+		coeff += m.Weight * float64(m.QueryFreq) * w * float64(m.DocFreq) * w
+	}
+
+	return coeff / docNorm
+}
+
+// BM25Scorer scores documents using Okapi BM25:
+//
+//	score(d, q) = sum over t in q of IDF(t) * (f(t,d) * (k1+1)) / (f(t,d) + k1 * (1 - b + b * |d|/avgdl))
+//	IDF(t) = log((N - n(t) + 0.5)/(n(t) + 0.5) + 1)
+//
+// BM25 tends to outperform CosineScorer on short queries. Use
+// NewBM25Scorer for the typical k1=1.2, b=0.75 defaults.
+type BM25Scorer struct {
+	// K1 controls term-frequency saturation.
+	K1 float64
+	// B controls document-length normalization.
+	B float64
+}
+
+// NewBM25Scorer returns a BM25Scorer configured with the typical
+// k1=1.2 and b=0.75 defaults.
+func NewBM25Scorer() *BM25Scorer {
+	return &BM25Scorer{K1: 1.2, B: 0.75}
+}
+
+// Score implements Scorer.
+func (s *BM25Scorer) Score(matches []TermMatch, docNorm, docLen, avgDocLen float64, totalDocs uint64) float64 {
+	var score float64
+
+	for _, m := range matches {
+		if m.DocFreq == 0 {
+			continue
+		}
+
+		n := float64(m.DocsSeen)
+
+		idf := math.Log((float64(totalDocs)-n+0.5)/(n+0.5) + 1)
+
+		f := float64(m.DocFreq)
+		norm := 1 - s.B + s.B*docLen/avgDocLen
+
+		score += m.Weight * idf * (f * (s.K1 + 1)) / (f + s.K1*norm)
+	}
+
+	return score
+}
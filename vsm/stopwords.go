@@ -0,0 +1,11 @@
+package vsm
+
+// EnglishStopwords is a small list of common English function words
+// carrying little discriminative weight in a corpus. It's the list
+// EnglishAnalyzer passes to NewStopwordFilter.
+var EnglishStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by",
+	"for", "if", "in", "into", "is", "it", "no", "not", "of",
+	"on", "or", "such", "that", "the", "their", "then", "there",
+	"these", "they", "this", "to", "was", "will", "with",
+}
@@ -0,0 +1,160 @@
+package vsm
+
+import "sync"
+
+// trieNode is one node of a trie over the corpus' term dictionary,
+// keyed by rune so non-ASCII terms are handled correctly.
+type trieNode struct {
+	children map[rune]*trieNode
+
+	// term is non-empty when this node terminates an indexed term,
+	// holding the term itself so callers don't need to rebuild it
+	// from the path of runes walked to reach the node.
+	term string
+}
+
+// trie is a term dictionary that can be searched for terms within a
+// given Levenshtein edit distance of a query term, used by
+// WithFuzziness to expand unmatched query terms into indexed ones.
+type trie struct {
+	mu   sync.RWMutex
+	root *trieNode
+}
+
+func newTrie() *trie {
+	return &trie{root: &trieNode{children: make(map[rune]*trieNode)}}
+}
+
+// Insert adds word to the dictionary. Inserting the same word twice
+// is harmless.
+func (t *trie) Insert(word string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.root
+	for _, r := range word {
+		child, ok := n.children[r]
+		if !ok {
+			child = &trieNode{children: make(map[rune]*trieNode)}
+			n.children[r] = child
+		}
+		n = child
+	}
+	n.term = word
+}
+
+// Remove drops word from the dictionary so it's no longer returned
+// by FuzzySearch, pruning any trie node left with neither a term nor
+// children by the removal. Removing a word not in the dictionary is
+// harmless.
+func (t *trie) Remove(word string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	removeRunes(t.root, []rune(word))
+}
+
+// removeRunes walks node along runes, clears the term at the word's
+// terminal node, then unwinds the call stack pruning every now-empty
+// node (no term, no children) it passes back through.
+func removeRunes(node *trieNode, runes []rune) bool {
+	if len(runes) == 0 {
+		node.term = ""
+	} else {
+		child, ok := node.children[runes[0]]
+		if !ok {
+			return false
+		}
+
+		if removeRunes(child, runes[1:]) {
+			delete(node.children, runes[0])
+		}
+	}
+
+	return node.term == "" && len(node.children) == 0
+}
+
+// fuzzyMatch is a dictionary term found within maxEdits of a query
+// term, along with the edit distance at which it was found.
+type fuzzyMatch struct {
+	Term  string
+	Edits int
+}
+
+// FuzzySearch returns every dictionary term within maxEdits
+// Levenshtein edit distance of word. It walks the trie depth-first,
+// maintaining the Levenshtein DP row for the path walked so far at
+// each node; a node is only descended into when some cell of its row
+// is still within maxEdits, which is exactly the automaton-pruning
+// Levenshtein-automaton-over-a-trie technique achieves without
+// needing to materialize the automaton's states up front.
+func (t *trie) FuzzySearch(word string, maxEdits int) []fuzzyMatch {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	runes := []rune(word)
+
+	row := make([]int, len(runes)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	var matches []fuzzyMatch
+	for r, child := range t.root.children {
+		fuzzyDFS(child, r, runes, row, maxEdits, &matches)
+	}
+
+	return matches
+}
+
+// fuzzyDFS extends prevRow, the Levenshtein DP row of the trie path
+// ending at node's parent, by one rune (r, the rune node is keyed
+// under), then recurses into node's children unless every cell of
+// the resulting row already exceeds maxEdits.
+func fuzzyDFS(node *trieNode, r rune, word []rune, prevRow []int, maxEdits int, matches *[]fuzzyMatch) {
+	n := len(word)
+
+	row := make([]int, n+1)
+	row[0] = prevRow[0] + 1
+
+	minCell := row[0]
+	for i := 1; i <= n; i++ {
+		substCost := 1
+		if word[i-1] == r {
+			substCost = 0
+		}
+
+		row[i] = min3(
+			row[i-1]+1,             // insertion
+			prevRow[i]+1,           // deletion
+			prevRow[i-1]+substCost, // substitution (or match)
+		)
+
+		if row[i] < minCell {
+			minCell = row[i]
+		}
+	}
+
+	if minCell > maxEdits {
+		return
+	}
+
+	if node.term != "" && row[n] <= maxEdits {
+		*matches = append(*matches, fuzzyMatch{Term: node.term, Edits: row[n]})
+	}
+
+	for nr, child := range node.children {
+		fuzzyDFS(child, nr, word, row, maxEdits, matches)
+	}
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
@@ -10,24 +10,42 @@
 // d(w₁, w₂, ... wN), where w = term weight.
 //
 // The term weighting scheme used in this package is the TFIDF:
-//	   w = term.Count * log(|Docs| / |{ d ∈ Docs | term ∈ d}|)
+//
+//	w = term.Count * (log(|Docs| / |{ d ∈ Docs | term ∈ d}|) + 1)
 //
 // Vector space model uses the deviation of angles
 // between each document vector and the query vector
 // to calculate their similarities by calculating
 // the cosine of the angle between the vectors.
 // So for each document vector dᵢ and a query vector q:
-//	  cos0 = dᵢ•q / ||dᵢ|| * ||q||
+//
+//	cos0 = dᵢ•q / ||dᵢ|| * ||q||
+//
 // where ||dᵢ|| = the magnitude of the document vector
 // and ||q|| = the magnitude of the query vector.
 //
 // See: http://www.minerazzi.com/tutorials/term-vector-1.pdf
+//
+// Scoring is pluggable through the Scorer interface. New defaults
+// to CosineScorer, the TF-IDF cosine scheme described above, but
+// also accepts BM25Scorer via WithScorer, which tends to perform
+// better on short queries.
+//
+// Tokenization is pluggable through the Analyzer interface. New
+// defaults to StandardAnalyzer (whitespace splitting plus
+// lowercasing); EnglishAnalyzer and NGramAnalyzer can be set with
+// WithAnalyzer.
+//
+// Search matches query terms exactly by default. Passing
+// WithFuzziness(n) also matches query terms absent from the corpus
+// against indexed terms up to n edits away, discounting their
+// contribution to the score the farther they are.
 package vsm
 
 import (
+	"container/heap"
 	"context"
 	"math"
-	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -38,15 +56,34 @@ import (
 // vector space model calculation.
 type VSM struct {
 	terms *terms
+	index *invertedIndex
+
+	// dict holds every term seen so far, letting Search expand an
+	// unmatched query term into nearby indexed ones when called with
+	// WithFuzziness.
+	dict *trie
 
-	mu   sync.RWMutex
-	docs []document
+	mu     sync.RWMutex
+	docs   map[docID]document
+	nextID docID
 
 	docsCount uint64
 
+	// totalTermCount is the sum of the length, in tokens, of every
+	// document trained so far. Combined with docsCount it yields the
+	// corpus' average document length, used by scorers such as BM25.
+	totalTermCount uint64
+
 	// transformer is used for filtering
 	// the documents and query sentences.
 	transformer transform.Transformer
+
+	// scorer ranks documents against a query. Defaults to CosineScorer.
+	scorer Scorer
+
+	// analyzer tokenizes sentences and queries into terms. Defaults
+	// to StandardAnalyzer.
+	analyzer Analyzer
 }
 
 // Document  holds a sentence, which is tokenized and
@@ -85,22 +122,59 @@ func (t *terms) Set(k string, v term) {
 	t.terms[k] = v
 }
 
+func (t *terms) Delete(k string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.terms, k)
+}
+
 type document struct {
 	Document
 
 	termFreq map[string]uint64
+
+	// docLen is the number of tokens in the document.
+	docLen uint64
+}
+
+// Option configures optional VSM behavior on New.
+type Option func(*VSM)
+
+// WithScorer overrides the Scorer used to rank documents against a
+// query. Defaults to CosineScorer.
+func WithScorer(scorer Scorer) Option {
+	return func(v *VSM) { v.scorer = scorer }
+}
+
+// WithAnalyzer overrides the Analyzer used to turn sentences and
+// queries into terms. Defaults to StandardAnalyzer.
+func WithAnalyzer(analyzer Analyzer) Option {
+	return func(v *VSM) { v.analyzer = analyzer }
 }
 
 // New returns a VSM structure used
 // for searching documents in the corpus.
 // The Transformer is used for filtering
-// the documents sentences and queries.
-func New(t transform.Transformer) *VSM {
+// the documents sentences and queries
+// before the Analyzer tokenizes them.
+// Documents are scored with CosineScorer
+// and tokenized with StandardAnalyzer
+// unless overridden with WithScorer or
+// WithAnalyzer.
+func New(t transform.Transformer, opts ...Option) *VSM {
 	vsm := &VSM{
 		terms:       &terms{sync.RWMutex{}, make(map[string]term)},
+		index:       newInvertedIndex(),
+		dict:        newTrie(),
 		mu:          sync.RWMutex{},
-		docs:        []document{},
+		docs:        make(map[docID]document),
 		transformer: t,
+		scorer:      CosineScorer{},
+		analyzer:    StandardAnalyzer(),
+	}
+
+	for _, opt := range opts {
+		opt(vsm)
 	}
 
 	return vsm
@@ -120,14 +194,23 @@ func (v *VSM) StaticTraining(dc Document) error {
 		return err
 	}
 
-	for _, trm := range strings.Split(sentence, " ") {
-		t := strings.ToLower(strings.TrimSpace(trm))
+	tokens := v.analyzer.Tokenize(sentence)
 
+	// v.terms, v.index and v.dict are held under v.mu for the rest
+	// of this function: Load swaps all three out wholesale, so
+	// mutating them outside the lock would race on the field
+	// assignments themselves even though each structure guards its
+	// own contents with its own mutex.
+	v.mu.Lock()
+
+	for _, t := range tokens {
 		if _, ok := v.terms.Get(t); !ok {
 			v.terms.Set(t, term{})
+			v.dict.Insert(t)
 		}
 
 		doc.termFreq[t]++
+		doc.docLen++
 
 		seenTerms[t] = struct{}{}
 	}
@@ -138,12 +221,21 @@ func (v *VSM) StaticTraining(dc Document) error {
 		v.terms.Set(trm, t)
 	}
 
-	v.mu.Lock()
-	v.docs = append(v.docs, doc)
-	v.mu.Unlock()
+	id := v.nextID
+	v.nextID++
+
+	for trm, freq := range doc.termFreq {
+		v.index.Add(trm, id, freq)
+	}
+
+	v.docs[id] = doc
 
 	atomic.StoreUint64(&v.docsCount, uint64(len(v.docs)))
 
+	v.mu.Unlock()
+
+	atomic.AddUint64(&v.totalTermCount, doc.docLen)
+
 	return nil
 }
 
@@ -158,7 +250,10 @@ type TrainingResult struct {
 // DynamicTraining receives a producer channel of Document used
 // for dynamically augmenting the corpus. It returns a TrainingResult
 // channel which can be used to check if an error occurred
-// during the training process.
+// during the training process. Sending each TrainingResult blocks
+// until the caller receives it or ctx is done, so a caller that
+// stops draining the returned channel without canceling ctx will
+// stall further training of docCh.
 func (v *VSM) DynamicTraining(ctx context.Context, docCh <-chan Document) <-chan TrainingResult {
 	trainCh := make(chan TrainingResult)
 
@@ -175,11 +270,10 @@ func (v *VSM) DynamicTraining(ctx context.Context, docCh <-chan Document) <-chan
 					return
 				}
 
-				// Try to send the result of the training.
-				// If no one is interested, discards this value.
 				select {
-				default:
 				case trainCh <- TrainingResult{Doc: doc, Err: v.StaticTraining(doc)}:
+				case <-ctx.Done():
+					return
 				}
 
 			case <-ctx.Done():
@@ -191,75 +285,256 @@ func (v *VSM) DynamicTraining(ctx context.Context, docCh <-chan Document) <-chan
 	return trainCh
 }
 
-// Search returns the most similar document from the corpus
-// with the query based on vector space model, or an error.
-// A nil Document means there's no similarity between any
-// document in the corpus and the query.
-func (v *VSM) Search(query string) (*Document, error) {
-	queryDoc := document{termFreq: make(map[string]uint64)}
+// SearchOption configures optional per-query Search behavior.
+type SearchOption func(*searchConfig)
+
+type searchConfig struct {
+	maxEdits int
+}
+
+// WithFuzziness lets a query term that doesn't appear in the corpus
+// match indexed terms within maxEdits Levenshtein edit distance
+// instead (typically 1 or 2). A fuzzily matched term contributes to
+// the score as if it were the query term, discounted by
+// 1/(1+edits). Terms present in the corpus are always matched
+// exactly regardless of maxEdits. A maxEdits of zero, the default,
+// disables fuzzy matching.
+func WithFuzziness(maxEdits int) SearchOption {
+	return func(c *searchConfig) { c.maxEdits = maxEdits }
+}
+
+// docNorm computes a document's TF-IDF vector magnitude from its
+// term frequencies and the corpus' current per-term docsSeen and
+// totalDocs. It must be recomputed on every Search rather than
+// cached at training time: a term's IDF shifts as more documents
+// carrying it are trained, so a snapshot taken when the document was
+// added would go stale, and for the very first documents trained it
+// would be zero outright.
+func (v *VSM) docNorm(doc document, totalDocs uint64) float64 {
+	var normSum float64
+	for trm, freq := range doc.termFreq {
+		t, _ := v.terms.Get(trm)
+
+		weight := float64(freq) * idf(totalDocs, t.docsSeen)
+		normSum += weight * weight
+	}
+	return math.Sqrt(normSum)
+}
+
+// idf returns the smoothed inverse document frequency of a term seen
+// in docsSeen of totalDocs documents, shared by docNorm and
+// CosineScorer so both sides of the cosine stay in the same vector
+// space. The +1 keeps it from collapsing to zero for a term that
+// currently appears in every known document (trivially true of any
+// term in a single-document corpus), which would otherwise zero out
+// that document's norm and drop it from every search permanently.
+func idf(totalDocs, docsSeen uint64) float64 {
+	return math.Log(float64(totalDocs)/float64(docsSeen)) + 1
+}
+
+// Search walks the postings of the query's terms in the inverted
+// index, scores every document that shares at least one term with
+// the query using the VSM's Scorer, and returns up to topK Results
+// ordered by Score descending. A topK of zero or less returns every
+// scored document. An empty, non-nil slice means no document in the
+// corpus has any term in common with the query. Pass WithFuzziness
+// to also match query terms absent from the corpus.
+func (v *VSM) Search(query string, topK int, opts ...SearchOption) ([]Result, error) {
+	var cfg searchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	queryFreq := make(map[string]uint64)
 
 	query, err := v.sanitize(query)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, term := range strings.Split(query, " ") {
-		t := strings.ToLower(strings.TrimSpace(term))
-
-		queryDoc.termFreq[t]++
+	for _, t := range v.analyzer.Tokenize(query) {
+		queryFreq[t]++
 	}
 
 	totalDocs := atomic.LoadUint64(&v.docsCount)
 
-	var querySum float64
-	for trm, freq := range queryDoc.termFreq {
+	var avgDocLen float64
+	if totalDocs > 0 {
+		avgDocLen = float64(atomic.LoadUint64(&v.totalTermCount)) / float64(totalDocs)
+	}
+
+	// Everything below reads v.terms, v.index, v.dict and v.docs, all
+	// of which Load swaps out wholesale; hold v.mu for the rest of
+	// Search so it can't race with a concurrent Load on those field
+	// assignments, the same way Delete already does.
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	// matchesByDoc accumulates, for every document sharing at least
+	// one term with the query, the per-term statistics the Scorer
+	// needs to rank it. Only postings of query terms, exact or
+	// fuzzily expanded, are visited.
+	matchesByDoc := make(map[docID][]TermMatch)
+
+	for trm, qf := range queryFreq {
 		t, ok := v.terms.Get(trm)
 		if !ok {
+			if cfg.maxEdits > 0 {
+				v.addFuzzyMatches(trm, qf, cfg.maxEdits, matchesByDoc)
+			}
 			continue
 		}
 
-		idf := math.Log(float64(totalDocs) / float64(t.docsSeen))
+		for _, p := range v.index.Get(trm) {
+			matchesByDoc[p.id] = append(matchesByDoc[p.id], TermMatch{
+				Term:      trm,
+				QueryFreq: qf,
+				DocFreq:   p.termFreq,
+				DocsSeen:  t.docsSeen,
+				Weight:    1,
+			})
+		}
+	}
+
+	h := &resultHeap{}
 
-		weight := float64(freq) * idf
+	for id, matches := range matchesByDoc {
+		doc, ok := v.docs[id]
+		if !ok {
+			continue
+		}
+
+		score := v.scorer.Score(matches, v.docNorm(doc, totalDocs), float64(doc.docLen), avgDocLen, totalDocs)
+		if score <= 0 {
+			continue
+		}
 
-		querySum += math.Pow(weight, 2)
+		result := Result{Document: doc.Document, Score: score}
+
+		if topK <= 0 || h.Len() < topK {
+			heap.Push(h, result)
+			continue
+		}
+
+		if score > (*h)[0].Score {
+			heap.Pop(h)
+			heap.Push(h, result)
+		}
+	}
+
+	results := make([]Result, h.Len())
+	for i := len(results) - 1; i >= 0; i-- {
+		results[i] = heap.Pop(h).(Result)
 	}
 
-	queryMag := math.Sqrt(querySum)
+	return results, nil
+}
 
-	var foundDoc *Document
+// addFuzzyMatches expands a query term absent from the corpus into
+// every indexed term within maxEdits of it, appending a discounted
+// TermMatch to matchesByDoc for each document containing one.
+func (v *VSM) addFuzzyMatches(trm string, qf uint64, maxEdits int, matchesByDoc map[docID][]TermMatch) {
+	for _, fm := range v.dict.FuzzySearch(trm, maxEdits) {
+		t, ok := v.terms.Get(fm.Term)
+		if !ok {
+			continue
+		}
 
-	var maxSim float64
-	v.mu.RLock()
-	for _, doc := range v.docs {
-		var docSum float64
-		var coeff float64
+		weight := 1 / float64(1+fm.Edits)
 
-		for trm, freq := range doc.termFreq {
-			t, _ := v.terms.Get(trm)
+		for _, p := range v.index.Get(fm.Term) {
+			matchesByDoc[p.id] = append(matchesByDoc[p.id], TermMatch{
+				Term:      fm.Term,
+				QueryFreq: qf,
+				DocFreq:   p.termFreq,
+				DocsSeen:  t.docsSeen,
+				Weight:    weight,
+			})
+		}
+	}
+}
 
-			idf := math.Log(float64(totalDocs) / float64(t.docsSeen))
+// resultHeap is a min-heap of Results ordered by Score, letting
+// Search keep only the topK highest-scoring documents while
+// visiting postings in no particular order.
+type resultHeap []Result
 
-			weight := float64(freq) * idf
+func (h resultHeap) Len() int            { return len(h) }
+func (h resultHeap) Less(i, j int) bool  { return h[i].Score < h[j].Score }
+func (h resultHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *resultHeap) Push(x interface{}) { *h = append(*h, x.(Result)) }
 
-			queryTermWeight := float64(queryDoc.termFreq[trm]) * idf
+func (h *resultHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
 
-			coeff += weight * queryTermWeight
+	return item
+}
 
-			docSum += math.Pow(float64(weight), 2)
+// Delete removes every document with the given Class from the
+// corpus, adjusting docsSeen and the inverted index's postings
+// accordingly. It returns nil even if no document matched class.
+func (v *VSM) Delete(class string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for id, doc := range v.docs {
+		if doc.Class != class {
+			continue
 		}
 
-		docMag := math.Sqrt(docSum)
+		for trm := range doc.termFreq {
+			v.index.Remove(trm, id)
 
-		sim := coeff / (docMag * queryMag)
-		if sim > maxSim {
-			foundDoc = &Document{Sentence: doc.Sentence, Class: doc.Class}
-			maxSim = sim
+			if t, ok := v.terms.Get(trm); ok && t.docsSeen > 0 {
+				t.docsSeen--
+				if t.docsSeen == 0 {
+					v.terms.Delete(trm)
+					v.dict.Remove(trm)
+				} else {
+					v.terms.Set(trm, t)
+				}
+			}
 		}
+
+		delete(v.docs, id)
+
+		atomic.AddUint64(&v.totalTermCount, negateUint64(doc.docLen))
 	}
-	v.mu.RUnlock()
 
-	return foundDoc, nil
+	atomic.StoreUint64(&v.docsCount, uint64(len(v.docs)))
+
+	return nil
+}
+
+// Update replaces any existing document with dc.Class by deleting
+// it, then training dc as if it were new. It returns an error if
+// retraining dc fails; in that case the old document is still gone.
+func (v *VSM) Update(dc Document) error {
+	if err := v.Delete(dc.Class); err != nil {
+		return err
+	}
+
+	return v.StaticTraining(dc)
+}
+
+// DocsCount returns the number of documents currently in the corpus.
+func (v *VSM) DocsCount() uint64 {
+	return atomic.LoadUint64(&v.docsCount)
+}
+
+// TotalTermCount returns the sum of the length, in tokens, of every
+// document currently in the corpus.
+func (v *VSM) TotalTermCount() uint64 {
+	return atomic.LoadUint64(&v.totalTermCount)
+}
+
+// negateUint64 returns n's two's complement negation, the idiomatic
+// way to subtract from a counter manipulated with sync/atomic.
+func negateUint64(n uint64) uint64 {
+	return ^(n - 1)
 }
 
 // sanatize applies the transformations to the sentence.
@@ -9,6 +9,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 	"unicode"
@@ -182,18 +183,237 @@ func TestVSMSearch(t *testing.T) {
 				}
 			}
 
-			got, err := vsm.Search(tc.query)
+			got, err := vsm.Search(tc.query, 1)
 			if err != nil {
 				t.Fatalf("Got error while searching for %q: %q.", tc.query, err)
 			}
 
-			if !reflect.DeepEqual(got, tc.want) {
-				t.Errorf("Got %+v classifier; want %+v.", got, tc.want)
+			var gotDoc *Document
+			if len(got) > 0 {
+				gotDoc = &got[0].Document
+			}
+
+			if !reflect.DeepEqual(gotDoc, tc.want) {
+				t.Errorf("Got %+v classifier; want %+v.", gotDoc, tc.want)
 			}
 		})
 	}
 }
 
+func TestVSMSearchBM25(t *testing.T) {
+	docs := []Document{
+		{
+			Sentence: "Shipment of gold damaged in a fire.",
+			Class:    "d1",
+		},
+		{
+			Sentence: "Delivery of silver arrived in a silver truck.",
+			Class:    "d2",
+		},
+		{
+			Sentence: "Shipment of gold arrived in a truck.",
+			Class:    "d3",
+		},
+	}
+
+	vsm := New(nil, WithScorer(NewBM25Scorer()))
+
+	for _, doc := range docs {
+		if err := vsm.StaticTraining(doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	got, err := vsm.Search("gold silver truck.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) == 0 {
+		t.Fatal("Got no results, want at least one.")
+	}
+
+	if want := "d2"; got[0].Class != want {
+		t.Errorf("Got %q class; want %q.", got[0].Class, want)
+	}
+}
+
+func TestVSMSearchTopK(t *testing.T) {
+	docs := []Document{
+		{
+			Sentence: "Shipment of gold damaged in a fire.",
+			Class:    "d1",
+		},
+		{
+			Sentence: "Delivery of silver arrived in a silver truck.",
+			Class:    "d2",
+		},
+		{
+			Sentence: "Shipment of gold arrived in a truck.",
+			Class:    "d3",
+		},
+	}
+
+	vsm := New(nil)
+
+	for _, doc := range docs {
+		if err := vsm.StaticTraining(doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	got, err := vsm.Search("shipment gold truck.", 2)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Got %d results; want 2.", len(got))
+	}
+
+	if got[0].Score < got[1].Score {
+		t.Errorf("Got results out of order: %+v.", got)
+	}
+}
+
+func TestVSMDelete(t *testing.T) {
+	vsm := New(nil)
+
+	docs := []Document{
+		{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"},
+		{Sentence: "Delivery of silver arrived in a truck.", Class: "d2"},
+	}
+
+	for _, doc := range docs {
+		if err := vsm.StaticTraining(doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	if err := vsm.Delete("d1"); err != nil {
+		t.Fatalf("Got error while deleting: %q; want nil.", err)
+	}
+
+	got, err := vsm.Search("gold fire.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("Got %+v results for a deleted document; want none.", got)
+	}
+
+	got, err = vsm.Search("silver truck.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 1 || got[0].Class != "d2" {
+		t.Errorf("Got %+v; want a single d2 result.", got)
+	}
+}
+
+// TestVSMDeletePrunesTerm covers the case where a deleted document
+// held the only occurrence of one of its terms: that term must drop
+// out of v.terms and v.dict, not just the inverted index's postings,
+// or both keep growing forever on a long-running corpus that trains
+// and deletes documents in a loop.
+func TestVSMDeletePrunesTerm(t *testing.T) {
+	vsm := New(nil)
+
+	if err := vsm.StaticTraining(Document{Sentence: "gold", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while training: %q; want nil.", err)
+	}
+
+	if err := vsm.Delete("d1"); err != nil {
+		t.Fatalf("Got error while deleting: %q; want nil.", err)
+	}
+
+	if _, ok := vsm.terms.Get("gold"); ok {
+		t.Errorf("Got term %q still in v.terms after its only document was deleted; want it pruned.", "gold")
+	}
+
+	if matches := vsm.dict.FuzzySearch("gold", 0); len(matches) != 0 {
+		t.Errorf("Got %+v from FuzzySearch after the only document containing the term was deleted; want none.", matches)
+	}
+}
+
+func TestVSMUpdate(t *testing.T) {
+	vsm := New(nil)
+
+	if err := vsm.StaticTraining(Document{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while training: %q; want nil.", err)
+	}
+
+	if err := vsm.Update(Document{Sentence: "Shipment of silver arrived in a truck.", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while updating: %q; want nil.", err)
+	}
+
+	got, err := vsm.Search("silver truck.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 1 || got[0].Sentence != "Shipment of silver arrived in a truck." {
+		t.Errorf("Got %+v; want the updated d1 document.", got)
+	}
+
+	got, err = vsm.Search("gold fire.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("Got %+v results for the document's old content; want none.", got)
+	}
+}
+
+// TestVSMConcurrentSearchAndMutation exercises Search running
+// concurrently with Delete and StaticTraining, the scenario that
+// used to race: Search iterates the postings slice the inverted
+// index hands back, while Delete shifts that same backing array in
+// place. Run with -race to catch a regression.
+func TestVSMConcurrentSearchAndMutation(t *testing.T) {
+	vsm := New(nil)
+
+	for i := 0; i < 10; i++ {
+		doc := Document{Sentence: fmt.Sprintf("shipment %d of gold and silver", i), Class: fmt.Sprintf("d%d", i)}
+		if err := vsm.StaticTraining(doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := vsm.Search("shipment gold silver", 5); err != nil {
+					t.Errorf("Got error while searching: %q; want nil.", err)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			class := fmt.Sprintf("d%d", i%10)
+			if err := vsm.Delete(class); err != nil {
+				t.Errorf("Got error while deleting: %q; want nil.", err)
+			}
+			if err := vsm.StaticTraining(Document{Sentence: "shipment of gold and silver", Class: class}); err != nil {
+				t.Errorf("Got error while training: %q; want nil.", err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
 // setupTransformer get a fileTest struct and returns a
 // transform.Transformer with all transformations configured
 // in the fileTest.Transform.
@@ -247,16 +467,16 @@ func TestVSMSearchFromFile(t *testing.T) {
 				}
 			}
 
-			doc, err := vsm.Search(tc.Query)
+			got, err := vsm.Search(tc.Query, 1)
 			if err != nil {
 				t.Fatalf("Got error while searching for %q: %q.", tc.Query, err)
 			}
 
-			if doc == nil {
+			if len(got) == 0 {
 				t.Fatalf("Got no document found for query: %q.", tc.Query)
 			}
 
-			if got := doc.Class; got != tc.Want {
+			if got := got[0].Class; got != tc.Want {
 				t.Errorf("Got %q class; want %q.", got, tc.Want)
 			}
 		})
@@ -266,7 +486,7 @@ func TestVSMSearchFromFile(t *testing.T) {
 func TestVSMSearchError(t *testing.T) {
 	vsm := New(&testingTransformer{err: errors.New("Testing Error")})
 
-	if _, err := vsm.Search("testing"); err == nil {
+	if _, err := vsm.Search("testing", 1); err == nil {
 		t.Error("Got error nil while searching, want not nil.")
 	}
 }
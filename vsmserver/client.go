@@ -0,0 +1,111 @@
+package vsmserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/quan-to/go-vsm/vsm"
+)
+
+// Client calls a vsmserver HTTP/JSON gateway. The zero value is not
+// usable; construct one with NewClient.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient returns a Client that calls the gateway at baseURL
+// (e.g. "http://localhost:8080"). httpClient may be nil, in which
+// case http.DefaultClient is used.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{baseURL: baseURL, http: httpClient}
+}
+
+// Train adds doc to the remote corpus.
+func (c *Client) Train(ctx context.Context, doc vsm.Document) error {
+	var resp trainResponse
+
+	if err := c.post(ctx, "/train", trainRequest{Sentence: doc.Sentence, Class: doc.Class}, &resp); err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("vsmserver: %s", resp.Error)
+	}
+
+	return nil
+}
+
+// Search ranks the remote corpus against query.
+func (c *Client) Search(ctx context.Context, query string, topK int) ([]vsm.Result, error) {
+	var resp searchResponse
+
+	if err := c.post(ctx, "/search", searchRequest{Query: query, TopK: topK}, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("vsmserver: %s", resp.Error)
+	}
+
+	results := make([]vsm.Result, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = vsm.Result{
+			Document: vsm.Document{Sentence: r.Sentence, Class: r.Class},
+			Score:    r.Score,
+		}
+	}
+
+	return results, nil
+}
+
+// Stats reports the remote corpus' current size.
+func (c *Client) Stats(ctx context.Context) (Stats, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/stats", nil)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	var resp statsResponse
+	if err := c.do(req, &resp); err != nil {
+		return Stats{}, err
+	}
+
+	return Stats{DocsCount: resp.DocsCount, TotalTermCount: resp.TotalTermCount}, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req, out)
+}
+
+func (c *Client) do(req *http.Request, out interface{}) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vsmserver: unexpected status %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,12 @@
+// Package vsmserver wraps a *vsm.VSM behind a gRPC service and an
+// HTTP/JSON gateway, so a trained corpus can be trained and queried
+// remotely instead of only in-process.
+//
+// Server holds the transport-agnostic request handling shared by
+// both; NewHTTPHandler exposes it over HTTP/JSON, and the generated
+// vsmserverpb.VSMServer implementation in grpc.go exposes it over
+// gRPC. Run `go generate ./...` to regenerate vsmserverpb from
+// vsmserver.proto after editing it.
+package vsmserver
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative vsmserver.proto
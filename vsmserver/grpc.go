@@ -0,0 +1,169 @@
+package vsmserver
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+
+	"github.com/quan-to/go-vsm/vsm"
+	pb "github.com/quan-to/go-vsm/vsmserver/vsmserverpb"
+)
+
+// grpcServer adapts a *Server to the pb.VSMServer interface
+// generated from vsmserver.proto. Use RegisterGRPC to expose one on
+// a *grpc.Server.
+type grpcServer struct {
+	pb.UnimplementedVSMServer
+
+	srv *Server
+}
+
+// RegisterGRPC registers s on gs, exposing it over gRPC as defined
+// in vsmserver.proto.
+func RegisterGRPC(gs *grpc.Server, s *Server) {
+	pb.RegisterVSMServer(gs, &grpcServer{srv: s})
+}
+
+// Train implements pb.VSMServer.
+func (g *grpcServer) Train(ctx context.Context, req *pb.TrainRequest) (*pb.TrainResponse, error) {
+	doc := vsm.Document{Sentence: req.Document.Sentence, Class: req.Document.Class}
+
+	resp := &pb.TrainResponse{}
+	if err := g.srv.Train(ctx, doc); err != nil {
+		resp.Error = err.Error()
+	}
+
+	return resp, nil
+}
+
+// TrainStream implements pb.VSMServer, relaying the incoming stream
+// of TrainRequests onto Server.TrainStream and its TrainingResults
+// back onto the outgoing stream.
+func (g *grpcServer) TrainStream(stream pb.VSM_TrainStreamServer) error {
+	ctx := stream.Context()
+
+	docCh := make(chan vsm.Document)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(docCh)
+
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case docCh <- vsm.Document{Sentence: req.Document.Sentence, Class: req.Document.Class}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for res := range g.srv.TrainStream(ctx, docCh) {
+		out := &pb.TrainingResult{
+			Document: &pb.Document{Sentence: res.Doc.Sentence, Class: res.Doc.Class},
+		}
+		if res.Err != nil {
+			out.Error = res.Err.Error()
+		}
+
+		if err := stream.Send(out); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// Search implements pb.VSMServer.
+func (g *grpcServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	results, err := g.srv.Search(ctx, req.Query, int(req.TopK))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchResponse{Results: make([]*pb.Result, len(results))}
+	for i, res := range results {
+		resp.Results[i] = &pb.Result{
+			Document: &pb.Document{Sentence: res.Sentence, Class: res.Class},
+			Score:    res.Score,
+		}
+	}
+
+	return resp, nil
+}
+
+// SearchStream implements pb.VSMServer, relaying the incoming stream
+// of SearchRequests onto Server.SearchStream and its results back
+// onto the outgoing stream.
+func (g *grpcServer) SearchStream(stream pb.VSM_SearchStreamServer) error {
+	ctx := stream.Context()
+
+	queryCh := make(chan SearchQuery)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(queryCh)
+
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case queryCh <- SearchQuery{Query: req.Query, TopK: int(req.TopK)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for res := range g.srv.SearchStream(ctx, queryCh) {
+		out := &pb.SearchResponse{Results: make([]*pb.Result, len(res.Results))}
+		for i, r := range res.Results {
+			out.Results[i] = &pb.Result{
+				Document: &pb.Document{Sentence: r.Sentence, Class: r.Class},
+				Score:    r.Score,
+			}
+		}
+		if res.Err != nil {
+			out.Error = res.Err.Error()
+		}
+
+		if err := stream.Send(out); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// Stats implements pb.VSMServer.
+func (g *grpcServer) Stats(ctx context.Context, req *pb.StatsRequest) (*pb.StatsResponse, error) {
+	stats := g.srv.Stats(ctx)
+
+	return &pb.StatsResponse{DocsCount: stats.DocsCount, TotalTermCount: stats.TotalTermCount}, nil
+}
@@ -0,0 +1,85 @@
+package vsmserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/quan-to/go-vsm/vsm"
+	pb "github.com/quan-to/go-vsm/vsmserver/vsmserverpb"
+)
+
+// grpcTestTransformer always fails, so that it can be used to force
+// a vsm.VSM.Search error and observe how it's surfaced over gRPC.
+type grpcTestTransformer struct{ err error }
+
+func (t *grpcTestTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	return 0, 0, t.err
+}
+
+func (t *grpcTestTransformer) Reset() {}
+
+// dialGRPCServer starts gs on an in-memory bufconn listener and
+// returns a client connection to it, closing both when the test
+// ends.
+func dialGRPCServer(t *testing.T, gs *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Got error dialing bufconn: %q; want nil.", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestGRPCSearchStreamError(t *testing.T) {
+	v := vsm.New(&grpcTestTransformer{err: errors.New("testing error")})
+	srv := New(v)
+
+	gs := grpc.NewServer()
+	RegisterGRPC(gs, srv)
+
+	client := pb.NewVSMClient(dialGRPCServer(t, gs))
+
+	stream, err := client.SearchStream(context.Background())
+	if err != nil {
+		t.Fatalf("Got error opening SearchStream: %q; want nil.", err)
+	}
+
+	if err := stream.Send(&pb.SearchRequest{Query: "gold fire."}); err != nil {
+		t.Fatalf("Got error sending SearchRequest: %q; want nil.", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("Got error closing send side: %q; want nil.", err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Got error receiving SearchResponse: %q; want nil.", err)
+	}
+
+	if resp.Error == "" {
+		t.Errorf("Got empty SearchResponse.Error; want the sanitize failure surfaced.")
+	}
+	if len(resp.Results) != 0 {
+		t.Errorf("Got %d results alongside an error; want none.", len(resp.Results))
+	}
+}
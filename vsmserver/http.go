@@ -0,0 +1,134 @@
+package vsmserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/quan-to/go-vsm/vsm"
+)
+
+// trainRequest is the JSON body POST /train expects.
+type trainRequest struct {
+	Sentence string `json:"sentence"`
+	Class    string `json:"class"`
+}
+
+// trainResponse is the JSON body POST /train returns.
+type trainResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// searchRequest is the JSON body POST /search expects.
+type searchRequest struct {
+	Query string `json:"query"`
+	TopK  int    `json:"top_k"`
+}
+
+// result is the JSON representation of a vsm.Result.
+type result struct {
+	Sentence string  `json:"sentence"`
+	Class    string  `json:"class"`
+	Score    float64 `json:"score"`
+}
+
+// searchResponse is the JSON body POST /search returns.
+type searchResponse struct {
+	Results []result `json:"results"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// statsResponse is the JSON body GET /stats returns.
+type statsResponse struct {
+	DocsCount      uint64 `json:"docs_count"`
+	TotalTermCount uint64 `json:"total_term_count"`
+}
+
+// NewHTTPHandler returns an http.Handler exposing s over HTTP/JSON:
+//
+//	POST /train  {"sentence": "...", "class": "..."}
+//	POST /search {"query": "...", "top_k": 5}
+//	GET  /stats
+//
+// It's a thin synchronous gateway; TrainStream and SearchStream have
+// no HTTP equivalent and are only reachable over gRPC.
+func NewHTTPHandler(s *Server) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/train", func(w http.ResponseWriter, r *http.Request) {
+		handleTrain(w, r, s)
+	})
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		handleSearch(w, r, s)
+	})
+
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		handleStats(w, r, s)
+	})
+
+	return mux
+}
+
+func handleTrain(w http.ResponseWriter, r *http.Request, s *Server) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req trainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	doc := vsm.Document{Sentence: req.Sentence, Class: req.Class}
+
+	var resp trainResponse
+	if err := s.Train(r.Context(), doc); err != nil {
+		resp.Error = err.Error()
+	}
+
+	writeJSON(w, resp)
+}
+
+func handleSearch(w http.ResponseWriter, r *http.Request, s *Server) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req searchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results, err := s.Search(r.Context(), req.Query, req.TopK)
+
+	resp := searchResponse{Results: make([]result, len(results))}
+	for i, res := range results {
+		resp.Results[i] = result{Sentence: res.Sentence, Class: res.Class, Score: res.Score}
+	}
+
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	writeJSON(w, resp)
+}
+
+func handleStats(w http.ResponseWriter, r *http.Request, s *Server) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stats := s.Stats(r.Context())
+
+	writeJSON(w, statsResponse{DocsCount: stats.DocsCount, TotalTermCount: stats.TotalTermCount})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
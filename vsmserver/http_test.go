@@ -0,0 +1,48 @@
+package vsmserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/quan-to/go-vsm/vsm"
+)
+
+func TestHTTPGateway(t *testing.T) {
+	srv := New(vsm.New(nil))
+
+	ts := httptest.NewServer(NewHTTPHandler(srv))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, nil)
+	ctx := context.Background()
+
+	docs := []vsm.Document{
+		{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"},
+		{Sentence: "Delivery of silver arrived in a truck.", Class: "d2"},
+	}
+
+	for _, doc := range docs {
+		if err := client.Train(ctx, doc); err != nil {
+			t.Fatalf("Got error while training: %q; want nil.", err)
+		}
+	}
+
+	got, err := client.Search(ctx, "gold fire.", 1)
+	if err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", err)
+	}
+
+	if len(got) != 1 || got[0].Class != "d1" {
+		t.Errorf("Got %+v; want a single d1 result.", got)
+	}
+
+	stats, err := client.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Got error while fetching stats: %q; want nil.", err)
+	}
+
+	if stats.DocsCount != 2 {
+		t.Errorf("Got %d docs; want 2.", stats.DocsCount)
+	}
+}
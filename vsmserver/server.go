@@ -0,0 +1,100 @@
+package vsmserver
+
+import (
+	"context"
+
+	"github.com/quan-to/go-vsm/vsm"
+)
+
+// Server adapts a *vsm.VSM to remote transports. It holds no
+// transport-specific state itself; NewHTTPHandler and the generated
+// vsmserverpb.VSMServer implementation in grpc.go both delegate to
+// it.
+type Server struct {
+	vsm *vsm.VSM
+}
+
+// New returns a Server backed by vsm. The VSM must already be
+// configured with whatever Transformer, Scorer and Analyzer the
+// caller wants; Server only adds a remote-callable surface on top.
+func New(v *vsm.VSM) *Server {
+	return &Server{vsm: v}
+}
+
+// Train adds doc to the corpus, mirroring vsm.VSM.StaticTraining.
+func (s *Server) Train(ctx context.Context, doc vsm.Document) error {
+	return s.vsm.StaticTraining(doc)
+}
+
+// TrainStream adds every Document received from docs to the corpus,
+// mirroring vsm.VSM.DynamicTraining, and returns a channel carrying
+// one TrainingResult per Document. The returned channel is closed
+// once docs is closed or ctx is done.
+func (s *Server) TrainStream(ctx context.Context, docs <-chan vsm.Document) <-chan vsm.TrainingResult {
+	return s.vsm.DynamicTraining(ctx, docs)
+}
+
+// Search ranks the corpus against query, mirroring vsm.VSM.Search.
+func (s *Server) Search(ctx context.Context, query string, topK int) ([]vsm.Result, error) {
+	return s.vsm.Search(query, topK)
+}
+
+// SearchStream ranks the corpus against every query received from
+// queries, returning a channel carrying one result (or error) per
+// query, in the order queries were received. The returned channel
+// is closed once queries is closed or ctx is done.
+func (s *Server) SearchStream(ctx context.Context, queries <-chan SearchQuery) <-chan SearchStreamResult {
+	resCh := make(chan SearchStreamResult)
+
+	go func() {
+		defer close(resCh)
+
+		for {
+			select {
+			case q, ok := <-queries:
+				if !ok {
+					return
+				}
+
+				results, err := s.vsm.Search(q.Query, q.TopK)
+
+				select {
+				case resCh <- SearchStreamResult{Results: results, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return resCh
+}
+
+// SearchQuery is one query submitted to SearchStream.
+type SearchQuery struct {
+	Query string
+	TopK  int
+}
+
+// SearchStreamResult is SearchStream's response to one SearchQuery.
+type SearchStreamResult struct {
+	Results []vsm.Result
+	Err     error
+}
+
+// Stats reports corpus-wide counters.
+type Stats struct {
+	DocsCount      uint64
+	TotalTermCount uint64
+}
+
+// Stats reports the corpus' current size.
+func (s *Server) Stats(ctx context.Context) Stats {
+	return Stats{
+		DocsCount:      s.vsm.DocsCount(),
+		TotalTermCount: s.vsm.TotalTermCount(),
+	}
+}
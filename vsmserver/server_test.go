@@ -0,0 +1,140 @@
+package vsmserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quan-to/go-vsm/vsm"
+)
+
+func TestServerTrainStream(t *testing.T) {
+	srv := New(vsm.New(nil))
+
+	docCh := make(chan vsm.Document)
+	resCh := srv.TrainStream(context.Background(), docCh)
+
+	docs := []vsm.Document{
+		{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"},
+		{Sentence: "Delivery of silver arrived in a truck.", Class: "d2"},
+	}
+
+	go func() {
+		defer close(docCh)
+		for _, doc := range docs {
+			docCh <- doc
+		}
+	}()
+
+	var got []vsm.TrainingResult
+	for res := range resCh {
+		if res.Err != nil {
+			t.Errorf("Got error while training: %q; want nil.", res.Err)
+		}
+		got = append(got, res)
+	}
+
+	if len(got) != len(docs) {
+		t.Errorf("Got %d training results; want %d.", len(got), len(docs))
+	}
+
+	if stats := srv.Stats(context.Background()); stats.DocsCount != 2 {
+		t.Errorf("Got %d docs; want 2.", stats.DocsCount)
+	}
+}
+
+func TestServerTrainStreamCancel(t *testing.T) {
+	srv := New(vsm.New(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	docCh := make(chan vsm.Document)
+	resCh := srv.TrainStream(ctx, docCh)
+
+	cancel()
+
+	select {
+	case _, ok := <-resCh:
+		if ok {
+			t.Errorf("Got a training result after the context was canceled; want the channel closed.")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for TrainStream to close its result channel after cancellation.")
+	}
+}
+
+func TestServerSearchStream(t *testing.T) {
+	v := vsm.New(nil)
+	if err := v.StaticTraining(vsm.Document{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while training: %q; want nil.", err)
+	}
+
+	srv := New(v)
+
+	queryCh := make(chan SearchQuery)
+	resCh := srv.SearchStream(context.Background(), queryCh)
+
+	go func() {
+		defer close(queryCh)
+		queryCh <- SearchQuery{Query: "gold fire.", TopK: 1}
+	}()
+
+	res, ok := <-resCh
+	if !ok {
+		t.Fatalf("Got a closed channel; want a SearchStreamResult.")
+	}
+
+	if res.Err != nil {
+		t.Fatalf("Got error while searching: %q; want nil.", res.Err)
+	}
+
+	if len(res.Results) != 1 || res.Results[0].Class != "d1" {
+		t.Errorf("Got %+v; want a single d1 result.", res.Results)
+	}
+
+	if _, ok := <-resCh; ok {
+		t.Errorf("Got a second result after queryCh was closed; want the channel closed.")
+	}
+}
+
+// TestServerSearchStreamBackpressure exercises the send side of
+// SearchStream's result channel: the caller submits a query and only
+// reads its result after a delay, so SearchStream must block on the
+// unbuffered resCh rather than drop the result while waiting for a
+// reader.
+func TestServerSearchStreamBackpressure(t *testing.T) {
+	v := vsm.New(nil)
+	if err := v.StaticTraining(vsm.Document{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"}); err != nil {
+		t.Fatalf("Got error while training: %q; want nil.", err)
+	}
+
+	srv := New(v)
+
+	queryCh := make(chan SearchQuery)
+	resCh := srv.SearchStream(context.Background(), queryCh)
+
+	queryCh <- SearchQuery{Query: "gold fire.", TopK: 1}
+
+	time.Sleep(50 * time.Millisecond)
+
+	select {
+	case res, ok := <-resCh:
+		if !ok {
+			t.Fatalf("Got a closed channel; want a SearchStreamResult.")
+		}
+		if res.Err != nil {
+			t.Errorf("Got error while searching: %q; want nil.", res.Err)
+		}
+		if len(res.Results) != 1 || res.Results[0].Class != "d1" {
+			t.Errorf("Got %+v; want a single d1 result.", res.Results)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the delayed result.")
+	}
+
+	close(queryCh)
+
+	if _, ok := <-resCh; ok {
+		t.Errorf("Got a result after queryCh was closed; want the channel closed.")
+	}
+}
@@ -0,0 +1,97 @@
+// Package vsmserverpb holds the message and service types generated
+// from vsmserver.proto.
+//
+// This file is hand-maintained rather than produced by `protoc
+// --go_out` because the protoc toolchain isn't available in every
+// environment this repo is built in. It targets the same wire
+// format protoc-gen-go would produce (legacy struct-tag reflection,
+// via github.com/golang/protobuf/proto) so it interoperates with any
+// real gRPC client or server speaking vsmserver.proto. Regenerate it
+// with `go generate ./...` once protoc and protoc-gen-go are on
+// PATH, and this note can go away.
+package vsmserverpb
+
+import "github.com/golang/protobuf/proto"
+
+// Document mirrors vsm.Document.
+type Document struct {
+	Sentence string `protobuf:"bytes,1,opt,name=sentence,proto3" json:"sentence,omitempty"`
+	Class    string `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+}
+
+func (m *Document) Reset()         { *m = Document{} }
+func (m *Document) String() string { return proto.CompactTextString(m) }
+func (*Document) ProtoMessage()    {}
+
+type TrainRequest struct {
+	Document *Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+}
+
+func (m *TrainRequest) Reset()         { *m = TrainRequest{} }
+func (m *TrainRequest) String() string { return proto.CompactTextString(m) }
+func (*TrainRequest) ProtoMessage()    {}
+
+// TrainResponse's error is empty when training succeeded.
+type TrainResponse struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *TrainResponse) Reset()         { *m = TrainResponse{} }
+func (m *TrainResponse) String() string { return proto.CompactTextString(m) }
+func (*TrainResponse) ProtoMessage()    {}
+
+// TrainingResult's error is empty when training this document succeeded.
+type TrainingResult struct {
+	Document *Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Error    string    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *TrainingResult) Reset()         { *m = TrainingResult{} }
+func (m *TrainingResult) String() string { return proto.CompactTextString(m) }
+func (*TrainingResult) ProtoMessage()    {}
+
+type SearchRequest struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// TopK caps the number of results. Zero or less returns every
+	// scored document, as vsm.VSM.Search does.
+	TopK int32 `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+
+// Result mirrors vsm.Result.
+type Result struct {
+	Document *Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Score    float64   `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (m *Result) Reset()         { *m = Result{} }
+func (m *Result) String() string { return proto.CompactTextString(m) }
+func (*Result) ProtoMessage()    {}
+
+type SearchResponse struct {
+	Results []*Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Error is empty when this query succeeded.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+
+type StatsRequest struct{}
+
+func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
+func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
+func (*StatsRequest) ProtoMessage()    {}
+
+type StatsResponse struct {
+	DocsCount      uint64 `protobuf:"varint,1,opt,name=docs_count,json=docsCount,proto3" json:"docs_count,omitempty"`
+	TotalTermCount uint64 `protobuf:"varint,2,opt,name=total_term_count,json=totalTermCount,proto3" json:"total_term_count,omitempty"`
+}
+
+func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
+func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()    {}
@@ -0,0 +1,303 @@
+// See the note in vsmserver.pb.go: this file is hand-maintained in
+// place of `protoc --go-grpc_out` output until protoc is available
+// to regenerate it from vsmserver.proto.
+package vsmserverpb
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	VSM_Train_FullMethodName        = "/vsmserver.VSM/Train"
+	VSM_TrainStream_FullMethodName  = "/vsmserver.VSM/TrainStream"
+	VSM_Search_FullMethodName       = "/vsmserver.VSM/Search"
+	VSM_SearchStream_FullMethodName = "/vsmserver.VSM/SearchStream"
+	VSM_Stats_FullMethodName        = "/vsmserver.VSM/Stats"
+)
+
+// VSMClient is the client API for the VSM service.
+type VSMClient interface {
+	Train(ctx context.Context, in *TrainRequest, opts ...grpc.CallOption) (*TrainResponse, error)
+	TrainStream(ctx context.Context, opts ...grpc.CallOption) (VSM_TrainStreamClient, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	SearchStream(ctx context.Context, opts ...grpc.CallOption) (VSM_SearchStreamClient, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+}
+
+type vSMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewVSMClient returns a VSMClient that invokes VSM's methods over cc.
+func NewVSMClient(cc grpc.ClientConnInterface) VSMClient {
+	return &vSMClient{cc}
+}
+
+func (c *vSMClient) Train(ctx context.Context, in *TrainRequest, opts ...grpc.CallOption) (*TrainResponse, error) {
+	out := new(TrainResponse)
+	if err := c.cc.Invoke(ctx, VSM_Train_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vSMClient) TrainStream(ctx context.Context, opts ...grpc.CallOption) (VSM_TrainStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VSM_ServiceDesc.Streams[0], VSM_TrainStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &vSMTrainStreamClient{stream}, nil
+}
+
+func (c *vSMClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	if err := c.cc.Invoke(ctx, VSM_Search_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vSMClient) SearchStream(ctx context.Context, opts ...grpc.CallOption) (VSM_SearchStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VSM_ServiceDesc.Streams[1], VSM_SearchStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &vSMSearchStreamClient{stream}, nil
+}
+
+func (c *vSMClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	if err := c.cc.Invoke(ctx, VSM_Stats_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VSM_TrainStreamClient is the client-side stream handle for TrainStream.
+type VSM_TrainStreamClient interface {
+	Send(*TrainRequest) error
+	Recv() (*TrainingResult, error)
+	grpc.ClientStream
+}
+
+type vSMTrainStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *vSMTrainStreamClient) Send(m *TrainRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vSMTrainStreamClient) Recv() (*TrainingResult, error) {
+	m := new(TrainingResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// VSM_SearchStreamClient is the client-side stream handle for SearchStream.
+type VSM_SearchStreamClient interface {
+	Send(*SearchRequest) error
+	Recv() (*SearchResponse, error)
+	grpc.ClientStream
+}
+
+type vSMSearchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *vSMSearchStreamClient) Send(m *SearchRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vSMSearchStreamClient) Recv() (*SearchResponse, error) {
+	m := new(SearchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// VSMServer is the server API for the VSM service. All
+// implementations should embed UnimplementedVSMServer for forward
+// compatibility.
+type VSMServer interface {
+	Train(context.Context, *TrainRequest) (*TrainResponse, error)
+	TrainStream(VSM_TrainStreamServer) error
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	SearchStream(VSM_SearchStreamServer) error
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+}
+
+// UnimplementedVSMServer should be embedded to have forward
+// compatible implementations.
+type UnimplementedVSMServer struct{}
+
+func (UnimplementedVSMServer) Train(context.Context, *TrainRequest) (*TrainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Train not implemented")
+}
+func (UnimplementedVSMServer) TrainStream(VSM_TrainStreamServer) error {
+	return status.Error(codes.Unimplemented, "method TrainStream not implemented")
+}
+func (UnimplementedVSMServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedVSMServer) SearchStream(VSM_SearchStreamServer) error {
+	return status.Error(codes.Unimplemented, "method SearchStream not implemented")
+}
+func (UnimplementedVSMServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Stats not implemented")
+}
+
+// RegisterVSMServer registers srv on s, exposing it as vsmserver.VSM.
+func RegisterVSMServer(s grpc.ServiceRegistrar, srv VSMServer) {
+	s.RegisterService(&VSM_ServiceDesc, srv)
+}
+
+func _VSM_Train_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VSMServer).Train(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: VSM_Train_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VSMServer).Train(ctx, req.(*TrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VSM_TrainStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VSMServer).TrainStream(&vSMTrainStreamServer{stream})
+}
+
+// VSM_TrainStreamServer is the server-side stream handle for TrainStream.
+type VSM_TrainStreamServer interface {
+	Send(*TrainingResult) error
+	Recv() (*TrainRequest, error)
+	grpc.ServerStream
+}
+
+type vSMTrainStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *vSMTrainStreamServer) Send(m *TrainingResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vSMTrainStreamServer) Recv() (*TrainRequest, error) {
+	m := new(TrainRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VSM_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VSMServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: VSM_Search_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VSMServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VSM_SearchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VSMServer).SearchStream(&vSMSearchStreamServer{stream})
+}
+
+// VSM_SearchStreamServer is the server-side stream handle for SearchStream.
+type VSM_SearchStreamServer interface {
+	Send(*SearchResponse) error
+	Recv() (*SearchRequest, error)
+	grpc.ServerStream
+}
+
+type vSMSearchStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *vSMSearchStreamServer) Send(m *SearchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vSMSearchStreamServer) Recv() (*SearchRequest, error) {
+	m := new(SearchRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VSM_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VSMServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: VSM_Stats_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VSMServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VSM_ServiceDesc is the grpc.ServiceDesc for the VSM service. It's
+// only intended for direct use with grpc.RegisterService, and not to
+// be introspected or modified (even as a copy).
+var VSM_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vsmserver.VSM",
+	HandlerType: (*VSMServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Train",
+			Handler:    _VSM_Train_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _VSM_Search_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _VSM_Stats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TrainStream",
+			Handler:       _VSM_TrainStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SearchStream",
+			Handler:       _VSM_SearchStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "vsmserver.proto",
+}